@@ -0,0 +1,146 @@
+package retries
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Backoff computes how long to wait before the next retry attempt, given the
+// attempt number (1-indexed) and the result/error from that attempt.
+//
+// When res is an *http.Response carrying a Retry-After header, implementations
+// in this package prefer the server-supplied value (parsed as either
+// delta-seconds or an HTTP-date) over their own computed delay, clamped to
+// their configured Cap when it is positive. ConstantBackoff has no Cap and
+// returns the Retry-After value unclamped.
+type Backoff interface {
+	Next(attempt int, res any, err error) time.Duration
+}
+
+// ConstantBackoff always waits the same Delay between attempts.
+type ConstantBackoff struct {
+	Delay time.Duration
+}
+
+// Next implements Backoff.
+func (b ConstantBackoff) Next(_ int, res any, _ error) time.Duration {
+	return withRetryAfter(res, 0, b.Delay)
+}
+
+// ExponentialBackoff implements "full jitter" exponential backoff:
+// sleep = rand(0, min(Cap, Base*2^(attempt-1))).
+//
+// See https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/.
+type ExponentialBackoff struct {
+	Base time.Duration
+	Cap  time.Duration
+}
+
+// Next implements Backoff.
+func (b ExponentialBackoff) Next(attempt int, res any, _ error) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	upper := b.Base * time.Duration(math.Pow(2, float64(attempt-1)))
+	if b.Cap > 0 && upper > b.Cap {
+		upper = b.Cap
+	}
+
+	var delay time.Duration
+	if upper > 0 {
+		delay = time.Duration(rand.Int63n(int64(upper)))
+	}
+
+	return withRetryAfter(res, b.Cap, delay)
+}
+
+// DecorrelatedJitterBackoff implements the "decorrelated jitter" formula:
+// sleep = min(Cap, rand(Base, prev*3)), seeded from the delay returned by the
+// previous call to Next.
+//
+// See https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/.
+type DecorrelatedJitterBackoff struct {
+	Base time.Duration
+	Cap  time.Duration
+
+	mu   sync.Mutex
+	prev time.Duration
+}
+
+// Next implements Backoff.
+func (b *DecorrelatedJitterBackoff) Next(_ int, res any, _ error) time.Duration {
+	b.mu.Lock()
+	prev := b.prev
+	if prev <= 0 {
+		prev = b.Base
+	}
+
+	upper := prev * 3
+	if upper <= b.Base {
+		upper = b.Base + 1
+	}
+
+	delay := b.Base + time.Duration(rand.Int63n(int64(upper-b.Base)))
+	if b.Cap > 0 && delay > b.Cap {
+		delay = b.Cap
+	}
+
+	b.prev = delay
+	b.mu.Unlock()
+
+	return withRetryAfter(res, b.Cap, delay)
+}
+
+// withRetryAfter returns the Retry-After delay carried by res, clamped to
+// cap (when cap > 0), or computed if res has no usable Retry-After header.
+func withRetryAfter(res any, cap time.Duration, computed time.Duration) time.Duration {
+	delay, ok := retryAfterDelay(res)
+	if !ok {
+		return computed
+	}
+
+	if cap > 0 && delay > cap {
+		delay = cap
+	}
+
+	return delay
+}
+
+// retryAfterDelay parses the Retry-After header on res, supporting both the
+// delta-seconds and HTTP-date forms defined in RFC 9110 section 10.2.3.
+func retryAfterDelay(res any) (time.Duration, bool) {
+	resp, ok := res.(*http.Response)
+	if !ok || resp == nil {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+
+		return time.Duration(secs) * time.Second, true
+	}
+
+	when, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	delay := time.Until(when)
+	if delay < 0 {
+		delay = 0
+	}
+
+	return delay, true
+}
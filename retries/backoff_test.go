@@ -0,0 +1,76 @@
+package retries_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"retry-pkg/retries"
+)
+
+func TestExponentialBackoffCap(t *testing.T) {
+	backoff := retries.ExponentialBackoff{Base: 10 * time.Millisecond, Cap: 50 * time.Millisecond}
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		delay := backoff.Next(attempt, nil, nil)
+		assert.LessOrEqual(t, delay, 50*time.Millisecond)
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+	}
+}
+
+func TestExponentialBackoffGrowsWithAttempt(t *testing.T) {
+	backoff := retries.ExponentialBackoff{Base: 10 * time.Millisecond, Cap: time.Hour}
+
+	// The upper bound of the jitter range should grow with attempt, even
+	// though any individual sample is random, so a late attempt should
+	// reliably be capable of a longer delay than attempt 1.
+	var sawLonger bool
+
+	for i := 0; i < 50; i++ {
+		if backoff.Next(10, nil, nil) > 10*time.Millisecond {
+			sawLonger = true
+			break
+		}
+	}
+
+	assert.True(t, sawLonger)
+}
+
+func TestDecorrelatedJitterBackoffCap(t *testing.T) {
+	backoff := &retries.DecorrelatedJitterBackoff{Base: 10 * time.Millisecond, Cap: 40 * time.Millisecond}
+
+	for i := 0; i < 20; i++ {
+		delay := backoff.Next(i+1, nil, nil)
+		assert.LessOrEqual(t, delay, 40*time.Millisecond)
+		assert.GreaterOrEqual(t, delay, 10*time.Millisecond)
+	}
+}
+
+func TestBackoffRetryAfterPrecedence(t *testing.T) {
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+
+	t.Run("constant backoff", func(t *testing.T) {
+		backoff := retries.ConstantBackoff{Delay: time.Second}
+		assert.Equal(t, 2*time.Second, backoff.Next(1, res, nil))
+	})
+
+	t.Run("exponential backoff", func(t *testing.T) {
+		backoff := retries.ExponentialBackoff{Base: time.Millisecond, Cap: time.Hour}
+		assert.Equal(t, 2*time.Second, backoff.Next(1, res, nil))
+	})
+
+	t.Run("respects cap", func(t *testing.T) {
+		backoff := retries.ExponentialBackoff{Base: time.Millisecond, Cap: time.Second}
+		assert.Equal(t, time.Second, backoff.Next(1, res, nil))
+	})
+
+	t.Run("http-date form", func(t *testing.T) {
+		future := time.Now().Add(3 * time.Second).UTC().Format(http.TimeFormat)
+		dateRes := &http.Response{Header: http.Header{"Retry-After": []string{future}}}
+		backoff := retries.ConstantBackoff{Delay: time.Millisecond}
+		delay := backoff.Next(1, dateRes, nil)
+		assert.Greater(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, 3*time.Second)
+	})
+}
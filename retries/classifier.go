@@ -0,0 +1,77 @@
+package retries
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+)
+
+// ErrorClassifier decides whether a low-level error returned by the wrapped
+// function (as opposed to an HTTP status code) should be retried.
+type ErrorClassifier interface {
+	IsRetryable(err error) bool
+}
+
+// ErrorClassifierFunc adapts a plain function to ErrorClassifier.
+type ErrorClassifierFunc func(err error) bool
+
+// IsRetryable implements ErrorClassifier.
+func (f ErrorClassifierFunc) IsRetryable(err error) bool {
+	return f(err)
+}
+
+// DefaultErrorClassifier retries common transient network/transport errors
+// (timeouts, connection resets, broken pipes, unexpected EOF), while treating
+// TLS/certificate verification failures as terminal since retrying them
+// cannot succeed without the underlying cert or trust store changing.
+var DefaultErrorClassifier ErrorClassifier = ErrorClassifierFunc(classifyError)
+
+func classifyError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var (
+		unknownAuthority x509.UnknownAuthorityError
+		hostnameErr      x509.HostnameError
+		certVerifyErr    *tls.CertificateVerificationError
+	)
+
+	if errors.As(err, &unknownAuthority) || errors.As(err, &hostnameErr) || errors.As(err, &certVerifyErr) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && (netErr.Timeout() || isTemporary(netErr)) {
+		return true
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	msg := err.Error()
+	if strings.Contains(msg, "broken pipe") || strings.Contains(msg, "connection reset by peer") {
+		return true
+	}
+
+	return false
+}
+
+// isTemporary reports err.Temporary() when the error implements it. The
+// method was deprecated from net.Error, but net/http and several transport
+// implementations still define it, so it remains a useful signal here.
+func isTemporary(err error) bool {
+	type temporary interface {
+		Temporary() bool
+	}
+
+	te, ok := err.(temporary)
+
+	return ok && te.Temporary()
+}
@@ -0,0 +1,67 @@
+package retries_test
+
+import (
+	"crypto/x509"
+	"errors"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"retry-pkg/retries"
+)
+
+func TestDefaultErrorClassifier(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{
+			name:      "connection reset",
+			err:       syscall.ECONNRESET,
+			retryable: true,
+		},
+		{
+			name:      "broken pipe",
+			err:       syscall.EPIPE,
+			retryable: true,
+		},
+		{
+			name:      "closed network connection",
+			err:       net.ErrClosed,
+			retryable: true,
+		},
+		{
+			name:      "broken pipe string match",
+			err:       errors.New("write: broken pipe"),
+			retryable: true,
+		},
+		{
+			name:      "connection reset by peer string match",
+			err:       errors.New("read: connection reset by peer"),
+			retryable: true,
+		},
+		{
+			name:      "unknown certificate authority",
+			err:       x509.UnknownAuthorityError{},
+			retryable: false,
+		},
+		{
+			name:      "hostname mismatch",
+			err:       x509.HostnameError{},
+			retryable: false,
+		},
+		{
+			name:      "unrelated error",
+			err:       errors.New("something else went wrong"),
+			retryable: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.retryable, retries.DefaultErrorClassifier.IsRetryable(test.err))
+		})
+	}
+}
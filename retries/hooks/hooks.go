@@ -0,0 +1,49 @@
+// Package hooks provides ready-made retries.Option sets for the two most
+// common OnRetry/OnGiveUp integrations: Prometheus metrics and structured
+// logging via log/slog.
+package hooks
+
+import (
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"retry-pkg/retries"
+)
+
+// PrometheusHooks returns retries.Options that count retry attempts and
+// give-ups as Prometheus counters, registered against reg.
+func PrometheusHooks(reg prometheus.Registerer) []retries.Option {
+	attempts := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "retries_attempts_total",
+		Help: "Number of retry attempts made by retries.Retry calls.",
+	})
+	giveUps := prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "retries_give_ups_total",
+		Help: "Number of retries.Retry calls that gave up without succeeding.",
+	})
+
+	reg.MustRegister(attempts, giveUps)
+
+	return []retries.Option{
+		retries.WithOnRetry(func(_ int, _ any, _ error) {
+			attempts.Inc()
+		}),
+		retries.WithOnGiveUp(func(_ any, _ error) {
+			giveUps.Inc()
+		}),
+	}
+}
+
+// SlogHooks returns retries.Options that log every retry and give-up
+// through logger at warn level, including the attempt number and error.
+func SlogHooks(logger *slog.Logger) []retries.Option {
+	return []retries.Option{
+		retries.WithOnRetry(func(attempt int, _ any, err error) {
+			logger.Warn("retrying", "attempt", attempt, "error", err)
+		}),
+		retries.WithOnGiveUp(func(_ any, err error) {
+			logger.Warn("giving up", "error", err)
+		}),
+	}
+}
@@ -0,0 +1,52 @@
+package retries_test
+
+import (
+	"context"
+	"net/http"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"retry-pkg/retries"
+)
+
+func TestRetryHooks(t *testing.T) {
+	ctx := context.TODO()
+
+	var (
+		retryAttempts []int
+		gaveUp        bool
+		loggedCodes   []int
+	)
+
+	calls := 0
+	_, result, err := retries.Retry(ctx, func() (*http.Response, error) {
+		calls++
+		// The first two attempts return a nil *http.Response, as a real
+		// transport failure would - this must not panic the response-log
+		// hook or the *http.Response type assertion in Retry.
+		if calls < 3 {
+			return nil, syscall.ECONNRESET
+		}
+
+		return &http.Response{StatusCode: http.StatusBadRequest}, nil
+	},
+		retries.WithOnRetry(func(attempt int, _ any, _ error) {
+			retryAttempts = append(retryAttempts, attempt)
+		}),
+		retries.WithOnGiveUp(func(_ any, _ error) {
+			gaveUp = true
+		}),
+		retries.WithResponseLogHook(func(res *http.Response) {
+			loggedCodes = append(loggedCodes, res.StatusCode)
+		}),
+	)
+
+	require.Error(t, err)
+	assert.Equal(t, []int{1, 2}, retryAttempts)
+	assert.True(t, gaveUp)
+	assert.Equal(t, []int{http.StatusBadRequest}, loggedCodes)
+	assert.Equal(t, 3, result.Attempts)
+	assert.Equal(t, http.StatusBadRequest, result.LastStatusCode)
+}
@@ -0,0 +1,176 @@
+// Package http wraps retries.Retry for the common case of retrying an
+// *http.Request, taking care of the one thing retries.Retry can't: replaying
+// the request body. A plain io.Reader is drained by the first attempt, so
+// Do buffers or reconstructs the body before every retry.
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"retry-pkg/retries"
+)
+
+// DefaultMaxBodyBytes is the size limit past which Do refuses to buffer a
+// streaming request body for replay, rather than silently truncating it.
+// Override it per call with WithMaxBodyBytes.
+const DefaultMaxBodyBytes = 10 << 20 // 10MiB
+
+// ErrBodyTooLarge is returned by Do when req's body exceeds the configured
+// max size and can't be safely buffered for retry.
+var ErrBodyTooLarge = errors.New("retries/http: request body too large to retry")
+
+// Option configures a single Do call.
+type Option func(*config)
+
+type config struct {
+	maxBodyBytes   int64
+	retryOpts      []retries.Option
+	requestLogHook func(req *http.Request)
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{maxBodyBytes: DefaultMaxBodyBytes}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// WithMaxBodyBytes overrides DefaultMaxBodyBytes for a single Do call.
+func WithMaxBodyBytes(n int64) Option {
+	return func(cfg *config) {
+		cfg.maxBodyBytes = n
+	}
+}
+
+// WithRetryOptions forwards retries.Options (WithPolicy, WithBackoff,
+// WithOnRetry, ...) to the underlying retries.Retry call.
+func WithRetryOptions(opts ...retries.Option) Option {
+	return func(cfg *config) {
+		cfg.retryOpts = append(cfg.retryOpts, opts...)
+	}
+}
+
+// WithRequestLogHook registers a hook that fires right before req is sent on
+// every attempt, including the first, e.g. to log the method and URL being
+// retried. It's the request-side counterpart to
+// retries.WithResponseLogHook, mirroring go-retryablehttp's
+// RequestLogHook/ResponseLogHook pair; it lives here rather than in the
+// generic retries package because retries.Retry has no visibility into the
+// request a caller's f sends.
+func WithRequestLogHook(hook func(req *http.Request)) Option {
+	return func(cfg *config) {
+		cfg.requestLogHook = hook
+	}
+}
+
+// Do executes req with client, retrying according to retries.Retry and
+// rewinding req's body before each attempt so POST/PUT requests survive
+// being retried.
+//
+// If req.GetBody is already set (as http.NewRequestWithContext sets it for
+// *bytes.Buffer, *bytes.Reader, and *strings.Reader bodies), Do calls it to get a
+// fresh body before every attempt. Otherwise it buffers req.Body once, up to
+// the configured max size, and replays that buffer; a body larger than the
+// limit fails with ErrBodyTooLarge rather than being silently truncated.
+//
+// Every response but the last is drained and closed before the next attempt,
+// so a retried connection can be reused instead of leaking a socket per
+// attempt. The final response, success or failure, is left for the caller to
+// read and close.
+//
+// WithRequestLogHook and WithRetryOptions' WithResponseLogHook together give
+// callers a hook on both sides of every attempt.
+func Do(ctx context.Context, client *http.Client, req *http.Request, opts ...Option) (*http.Response, error) {
+	cfg := newConfig(opts)
+
+	getBody, err := bodyFactory(req, cfg.maxBodyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var prevRes *http.Response
+
+	res, _, err := retries.Retry(ctx, func() (*http.Response, error) {
+		// Drain and close the previous attempt's response so its connection
+		// can be reused instead of leaking a socket per retry (the final
+		// response, if any, is left open for the caller to read and close).
+		if prevRes != nil {
+			drainAndClose(prevRes)
+			prevRes = nil
+		}
+
+		if getBody != nil {
+			body, err := getBody()
+			if err != nil {
+				return nil, fmt.Errorf("retries/http: rewinding request body: %w", err)
+			}
+
+			req.Body = body
+		}
+
+		outgoing := req.WithContext(ctx)
+
+		if cfg.requestLogHook != nil {
+			cfg.requestLogHook(outgoing)
+		}
+
+		res, err := client.Do(outgoing)
+		prevRes = res
+
+		return res, err
+	}, cfg.retryOpts...)
+
+	return res, err
+}
+
+// drainAndClose discards the remainder of res.Body and closes it so the
+// underlying connection becomes eligible for reuse, mirroring the pattern
+// go-retryablehttp uses between retry attempts.
+func drainAndClose(res *http.Response) {
+	if res == nil || res.Body == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+}
+
+// bodyFactory returns a function producing a fresh, independent copy of
+// req.Body for each attempt, or nil if req has no body to replay.
+func bodyFactory(req *http.Request, maxBodyBytes int64) (func() (io.ReadCloser, error), error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		return req.GetBody, nil
+	}
+
+	buf, err := io.ReadAll(io.LimitReader(req.Body, maxBodyBytes+1))
+	closeErr := req.Body.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("retries/http: buffering request body: %w", err)
+	}
+
+	if closeErr != nil {
+		return nil, fmt.Errorf("retries/http: closing request body: %w", closeErr)
+	}
+
+	if int64(len(buf)) > maxBodyBytes {
+		return nil, ErrBodyTooLarge
+	}
+
+	req.ContentLength = int64(len(buf))
+
+	return func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf)), nil
+	}, nil
+}
@@ -0,0 +1,133 @@
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rhttp "retry-pkg/retries/http"
+)
+
+func TestDoReplaysBodyOnRetry(t *testing.T) {
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+
+		if len(bodies) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, io.NopCloser(strings.NewReader("payload")))
+	require.NoError(t, err)
+
+	res, err := rhttp.Do(context.TODO(), server.Client(), req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, []string{"payload", "payload", "payload"}, bodies)
+}
+
+func TestDoReusesConnectionAcrossRetries(t *testing.T) {
+	var (
+		requests int
+		newConns int
+	)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.Header().Set("Content-Length", "5")
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("error"))
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			newConns++
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	res, err := rhttp.Do(context.TODO(), server.Client(), req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, 1, newConns)
+}
+
+func TestDoRequestLogHookFiresPerAttempt(t *testing.T) {
+	attempts := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var loggedURLs []string
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	res, err := rhttp.Do(context.TODO(), server.Client(), req, rhttp.WithRequestLogHook(func(req *http.Request) {
+		loggedURLs = append(loggedURLs, req.URL.String())
+	}))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, []string{server.URL, server.URL}, loggedURLs)
+}
+
+func TestDoReturnsErrorOnConnectionFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	addr := server.URL
+	server.Close() // nothing is listening on addr anymore
+
+	req, err := http.NewRequest(http.MethodGet, addr, nil)
+	require.NoError(t, err)
+
+	res, err := rhttp.Do(context.TODO(), http.DefaultClient, req)
+	require.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestDoRejectsOversizedBody(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "http://example.com", io.NopCloser(bytes.NewReader([]byte("0123456789"))))
+	require.NoError(t, err)
+
+	_, err = rhttp.Do(context.TODO(), http.DefaultClient, req, rhttp.WithMaxBodyBytes(4))
+	require.ErrorIs(t, err, rhttp.ErrBodyTooLarge)
+}
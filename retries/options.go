@@ -0,0 +1,79 @@
+package retries
+
+import "net/http"
+
+// Option configures the behavior of Retry. Options are applied in the order
+// they're passed, so later options win when they touch the same setting.
+type Option func(*config)
+
+// config holds the resolved settings for a single Retry call.
+type config struct {
+	policy          RetryPolicy
+	backoff         Backoff
+	attempts        uint
+	onRetry         func(attempt int, res any, err error)
+	onGiveUp        func(res any, err error)
+	responseLogHook func(res *http.Response)
+}
+
+func newConfig(opts []Option) *config {
+	cfg := &config{policy: DefaultPolicy{}}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// WithPolicy overrides the RetryPolicy used to decide whether an attempt
+// should be retried. The default is DefaultPolicy.
+func WithPolicy(policy RetryPolicy) Option {
+	return func(cfg *config) {
+		cfg.policy = policy
+	}
+}
+
+// WithBackoff overrides the Backoff used to compute the delay between
+// attempts. If unset, Retry falls back to retry-go's default delay
+// strategy.
+func WithBackoff(backoff Backoff) Option {
+	return func(cfg *config) {
+		cfg.backoff = backoff
+	}
+}
+
+// WithAttempts overrides the maximum number of attempts Retry makes before
+// giving up. If unset, Retry falls back to retry-go's default of 10.
+func WithAttempts(n uint) Option {
+	return func(cfg *config) {
+		cfg.attempts = n
+	}
+}
+
+// WithOnRetry registers a hook that fires from inside the retry loop
+// whenever an attempt failed and Retry is about to try again, e.g. to emit a
+// metric or a structured log line. attempt is 1-indexed and identifies the
+// attempt that just failed.
+func WithOnRetry(hook func(attempt int, res any, err error)) Option {
+	return func(cfg *config) {
+		cfg.onRetry = hook
+	}
+}
+
+// WithOnGiveUp registers a hook that fires once, after the final attempt,
+// when Retry is returning a non-nil error - whether because the policy
+// deemed the failure unrecoverable or because attempts were exhausted.
+func WithOnGiveUp(hook func(res any, err error)) Option {
+	return func(cfg *config) {
+		cfg.onGiveUp = hook
+	}
+}
+
+// WithResponseLogHook registers a hook that fires after every attempt whose
+// result is an *http.Response, regardless of whether that attempt succeeds,
+// retries, or gives up. It mirrors go-retryablehttp's ResponseLogHook.
+func WithResponseLogHook(hook func(res *http.Response)) Option {
+	return func(cfg *config) {
+		cfg.responseLogHook = hook
+	}
+}
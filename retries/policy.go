@@ -0,0 +1,170 @@
+package retries
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// RetryPolicy decides whether a failed attempt should be retried.
+//
+// ShouldRetry is called once per attempt with the raw result and error as
+// returned by the wrapped function. retry reports whether Retry should try
+// again; reason is the error that should be surfaced to the caller if this
+// was the last attempt (or if retry is false). reason may be nil even when
+// retry is false, e.g. for a plain success.
+//
+// reason should not be nil when retry is true and err is nil (i.e. when
+// ShouldRetry itself is the one deciding a nil-error result is still worth
+// retrying) - Retry substitutes a generic error in that case so it doesn't
+// read as a nil-error success to the underlying retry-go loop, but a real
+// reason produces a more useful Result.Errors entry.
+type RetryPolicy interface {
+	ShouldRetry(ctx context.Context, attempt int, res any, err error) (retry bool, reason error)
+}
+
+// DefaultPolicy mirrors the historical behavior of Retry: network errors and
+// HTTP 5xx responses other than 501 are retried, while 400, 403, 404 and 501
+// responses are treated as unrecoverable. Any other response, including
+// other 4xx codes, is accepted without retrying and without an error.
+//
+// Non-nil errors from the wrapped function are run through ErrorClassifier
+// (DefaultErrorClassifier if unset) to tell transient transport failures
+// apart from terminal ones such as certificate verification errors.
+type DefaultPolicy struct {
+	ErrorClassifier ErrorClassifier
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p DefaultPolicy) ShouldRetry(_ context.Context, _ int, res any, err error) (bool, error) {
+	if err != nil {
+		classifier := p.ErrorClassifier
+		if classifier == nil {
+			classifier = DefaultErrorClassifier
+		}
+
+		return classifier.IsRetryable(err), err
+	}
+
+	resp, ok := res.(*http.Response)
+	if !ok {
+		return false, nil
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotImplemented:
+		return false, fmt.Errorf("HTTP failed: %w", ErrNotImplemented)
+	case http.StatusBadRequest:
+		return false, fmt.Errorf("HTTP failed: %w", ErrBadRequest)
+	case http.StatusForbidden:
+		return false, fmt.Errorf("HTTP failed: %w", ErrForbidden)
+	case http.StatusNotFound:
+		return false, fmt.Errorf("HTTP failed: %w", ErrNotFound)
+	default:
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return true, fmt.Errorf("HTTP failed: server error - Status Code: %d", resp.StatusCode)
+		}
+
+		return false, nil
+	}
+}
+
+// Retry429And408Policy wraps a base policy (DefaultPolicy if Base is nil)
+// and additionally retries HTTP 429 (Too Many Requests) and 408 (Request
+// Timeout) responses, which are transient by nature even though they fall
+// outside the 5xx range.
+type Retry429And408Policy struct {
+	Base RetryPolicy
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p Retry429And408Policy) ShouldRetry(ctx context.Context, attempt int, res any, err error) (bool, error) {
+	if resp, ok := res.(*http.Response); ok && err == nil {
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests, http.StatusRequestTimeout:
+			return true, fmt.Errorf("HTTP failed: retryable status - Status Code: %d", resp.StatusCode)
+		}
+	}
+
+	return p.base().ShouldRetry(ctx, attempt, res, err)
+}
+
+func (p Retry429And408Policy) base() RetryPolicy {
+	if p.Base == nil {
+		return DefaultPolicy{}
+	}
+
+	return p.Base
+}
+
+// IdempotentPolicy wraps a base policy (DefaultPolicy if Base is nil) and
+// refuses to retry once a real response has come back for a non-idempotent
+// operation, since replaying it could duplicate a side effect. Network
+// errors, which mean no response reached the caller, are still delegated to
+// Base.
+type IdempotentPolicy struct {
+	Idempotent bool
+	Base       RetryPolicy
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p IdempotentPolicy) ShouldRetry(ctx context.Context, attempt int, res any, err error) (bool, error) {
+	retry, reason := p.base().ShouldRetry(ctx, attempt, res, err)
+
+	if !p.Idempotent && err == nil {
+		if _, ok := res.(*http.Response); ok {
+			return false, reason
+		}
+	}
+
+	return retry, reason
+}
+
+func (p IdempotentPolicy) base() RetryPolicy {
+	if p.Base == nil {
+		return DefaultPolicy{}
+	}
+
+	return p.Base
+}
+
+// ResponseHandler inspects a response that the base policy already
+// considered a success and reports an error if it should be retried anyway,
+// e.g. because the body failed to parse. It mirrors go-retryablehttp's
+// SetResponseHandler.
+type ResponseHandler func(res *http.Response) error
+
+// ExtendedPolicy wraps a base policy (DefaultPolicy if Base is nil) and
+// additionally runs ResponseHandler against responses the base policy
+// accepted, so callers can fail the attempt based on the response body.
+type ExtendedPolicy struct {
+	Base            RetryPolicy
+	ResponseHandler ResponseHandler
+}
+
+// ShouldRetry implements RetryPolicy.
+func (p ExtendedPolicy) ShouldRetry(ctx context.Context, attempt int, res any, err error) (bool, error) {
+	retry, reason := p.base().ShouldRetry(ctx, attempt, res, err)
+	if retry || err != nil || reason != nil || p.ResponseHandler == nil {
+		return retry, reason
+	}
+
+	resp, ok := res.(*http.Response)
+	if !ok {
+		return retry, reason
+	}
+
+	if handlerErr := p.ResponseHandler(resp); handlerErr != nil {
+		return true, fmt.Errorf("response handler: %w", handlerErr)
+	}
+
+	return retry, reason
+}
+
+func (p ExtendedPolicy) base() RetryPolicy {
+	if p.Base == nil {
+		return DefaultPolicy{}
+	}
+
+	return p.Base
+}
@@ -0,0 +1,98 @@
+package retries_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"retry-pkg/retries"
+)
+
+func TestDefaultPolicy(t *testing.T) {
+	policy := retries.DefaultPolicy{}
+	ctx := context.TODO()
+
+	t.Run("classified network error retries", func(t *testing.T) {
+		retry, reason := policy.ShouldRetry(ctx, 1, nil, syscall.ECONNRESET)
+		assert.True(t, retry)
+		assert.Error(t, reason)
+	})
+
+	t.Run("unclassified error does not retry", func(t *testing.T) {
+		retry, reason := policy.ShouldRetry(ctx, 1, nil, errors.New("boom"))
+		assert.False(t, retry)
+		assert.Error(t, reason)
+	})
+
+	t.Run("5xx retries", func(t *testing.T) {
+		retry, reason := policy.ShouldRetry(ctx, 1, &http.Response{StatusCode: http.StatusInternalServerError}, nil)
+		assert.True(t, retry)
+		assert.Error(t, reason)
+	})
+
+	t.Run("4xx is unrecoverable", func(t *testing.T) {
+		retry, reason := policy.ShouldRetry(ctx, 1, &http.Response{StatusCode: http.StatusBadRequest}, nil)
+		assert.False(t, retry)
+		assert.ErrorIs(t, reason, retries.ErrBadRequest)
+	})
+
+	t.Run("2xx succeeds", func(t *testing.T) {
+		retry, reason := policy.ShouldRetry(ctx, 1, &http.Response{StatusCode: http.StatusOK}, nil)
+		assert.False(t, retry)
+		assert.NoError(t, reason)
+	})
+}
+
+func TestRetry429And408Policy(t *testing.T) {
+	policy := retries.Retry429And408Policy{}
+	ctx := context.TODO()
+
+	for _, code := range []int{http.StatusTooManyRequests, http.StatusRequestTimeout} {
+		retry, reason := policy.ShouldRetry(ctx, 1, &http.Response{StatusCode: code}, nil)
+		assert.True(t, retry)
+		assert.Error(t, reason)
+	}
+}
+
+func TestIdempotentPolicy(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("non-idempotent request with a real response never retries", func(t *testing.T) {
+		policy := retries.IdempotentPolicy{Idempotent: false}
+		retry, _ := policy.ShouldRetry(ctx, 1, &http.Response{StatusCode: http.StatusInternalServerError}, nil)
+		assert.False(t, retry)
+	})
+
+	t.Run("non-idempotent request with a network error still retries", func(t *testing.T) {
+		policy := retries.IdempotentPolicy{Idempotent: false}
+		retry, _ := policy.ShouldRetry(ctx, 1, nil, syscall.ECONNRESET)
+		assert.True(t, retry)
+	})
+
+	t.Run("idempotent request retries as normal", func(t *testing.T) {
+		policy := retries.IdempotentPolicy{Idempotent: true}
+		retry, _ := policy.ShouldRetry(ctx, 1, &http.Response{StatusCode: http.StatusInternalServerError}, nil)
+		assert.True(t, retry)
+	})
+}
+
+func TestExtendedPolicy(t *testing.T) {
+	ctx := context.TODO()
+
+	policy := retries.ExtendedPolicy{
+		ResponseHandler: func(res *http.Response) error {
+			if res.StatusCode == http.StatusOK {
+				return errors.New("body failed validation")
+			}
+
+			return nil
+		},
+	}
+
+	retry, reason := policy.ShouldRetry(ctx, 1, &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.True(t, retry)
+	assert.Error(t, reason)
+}
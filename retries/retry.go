@@ -3,11 +3,25 @@
 //
 // This package offers a generic Retry function that can work with any return type,
 // implementing smart retry logic that distinguishes between recoverable and
-// unrecoverable errors. HTTP responses are handled intelligently:
+// unrecoverable errors. By default, HTTP responses are handled intelligently:
 //   - 2xx: Success, no retry needed
 //   - 4xx: Client errors, marked as unrecoverable (no retry)
 //   - 5xx: Server errors, will retry up to the configured limit
 //
+// This decision logic lives behind the RetryPolicy interface, which can be
+// swapped out via WithPolicy to compose different retry behaviors (see
+// DefaultPolicy, Retry429And408Policy, IdempotentPolicy, and ExtendedPolicy).
+//
+// Errors returned by the wrapped function (as opposed to status codes on a
+// successful response) are run through an ErrorClassifier before
+// DefaultPolicy retries them. This is a behavior change from earlier
+// versions of this package, which retried any error not explicitly wrapped
+// with retry.Unrecoverable: a plain errors.New or a driver error that isn't
+// recognized as a transient network/transport failure by
+// DefaultErrorClassifier is no longer retried by default. Callers that
+// depend on the old any-error-is-retryable behavior should supply their own
+// ErrorClassifier (or RetryPolicy) via WithPolicy.
+//
 // The package uses the github.com/avast/retry-go library under the hood and extends
 // it with HTTP-aware error handling and predefined error variables for common
 // HTTP status codes.
@@ -16,18 +30,12 @@ package retries
 import (
 	"context"
 	"errors"
-	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/avast/retry-go"
 )
 
-// count tracks the number of retry attempts for the current operation.
-// This is used primarily for testing purposes to verify retry behavior.
-// Note: This is a global variable, which means it's not thread-safe, but since
-// it's intended for testing scenarios, this is acceptable.
-var count int
-
 // HTTP error variables for common status codes.
 // These predefined errors provide consistent error messages
 // across the application when handling HTTP-related failures.
@@ -49,112 +57,141 @@ var (
 	ErrNotImplemented = errors.New("not implemented - Status Code: 501")
 )
 
+// errRetryWithoutReason stands in for an attempt error when a RetryPolicy
+// asks to retry but reports no reason and f returned a nil error. See the
+// ShouldRetry doc for why this shouldn't happen, but Retry guards against it
+// anyway since retry-go would otherwise read a nil error as success.
+var errRetryWithoutReason = errors.New("retries: policy requested a retry without a reason")
+
+// Result captures diagnostic information about a single Retry call. Unlike
+// the global counter this replaces, a Result is local to its call and safe
+// to inspect from concurrent goroutines running their own Retry calls.
+type Result[T any] struct {
+	// Value is the same result Retry returns directly; it's duplicated here
+	// so a Result can be logged or passed around as a single self-contained
+	// value.
+	Value T
+
+	// Attempts is the number of times f was invoked.
+	Attempts int
+
+	// TotalDuration is the wall-clock time spent across all attempts.
+	TotalDuration time.Duration
+
+	// LastStatusCode is the StatusCode of the last *http.Response result, or
+	// 0 if T isn't an *http.Response.
+	LastStatusCode int
+
+	// Errors holds one entry per attempt that didn't succeed, in order.
+	Errors []error
+}
+
 // Retry executes a function with automatic retry logic and intelligent error handling.
 // It uses Go generics to work with any return type T.
 //
-// The function will retry up to 5 times by default, but only for recoverable errors.
-// HTTP responses are handled intelligently:
-// - 2xx: Success, no retry
-// - 4xx: Client error, marked as unrecoverable (no retry)
-// - 5xx: Server error, will retry
+// The function will retry up to 10 times by default (retry-go's default;
+// override with WithAttempts), but only for attempts the active RetryPolicy
+// considers recoverable. DefaultPolicy is used unless overridden with
+// WithPolicy.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeout control
 //   - f: The function to execute, must return (T, error)
-//   - opts: Optional retry-go configuration options
+//   - opts: Options configuring this call, e.g. WithPolicy, WithAttempts
 //
 // Returns:
 //   - T: The result from the successful function execution
+//   - Result[T]: Diagnostics about this call, usable from any goroutine
 //   - error: Any error that occurred, or nil on success
-func Retry[T any](ctx context.Context, f func() (T, error), opts ...retry.Option) (T, error) {
+func Retry[T any](ctx context.Context, f func() (T, error), opts ...Option) (T, Result[T], error) {
+	cfg := newConfig(opts)
+
 	var (
-		res T
-		err error
+		res            T
+		err            error
+		attempt        int
+		attemptErrs    []error
+		lastStatusCode int
 	)
 
-	count = 0
+	retryOpts := []retry.Option{retry.RetryIf(retry.IsRecoverable)}
+	if cfg.backoff != nil {
+		retryOpts = append(retryOpts, retry.DelayType(func(n uint, _ error, _ *retry.Config) time.Duration {
+			return cfg.backoff.Next(int(n)+1, res, err)
+		}))
+	}
+	if cfg.attempts != 0 {
+		retryOpts = append(retryOpts, retry.Attempts(cfg.attempts))
+	}
+
+	maxAttempts := cfg.attempts
+	if maxAttempts == 0 {
+		maxAttempts = 10 // retry-go's default, see WithAttempts
+	}
+
+	start := time.Now()
 	err = retry.Do(
 		func() error {
+			attempt++
 			res, err = f()
-			res, err = checkResAndErr(res, err)
 
-			return err
-		},
-		retry.RetryIf(retryAction),
-	)
+			if resp, ok := any(res).(*http.Response); ok && resp != nil {
+				lastStatusCode = resp.StatusCode
 
-	return res, err
-}
+				if cfg.responseLogHook != nil {
+					cfg.responseLogHook(resp)
+				}
+			}
 
-// checkResAndErr analyzes the response and error to determine retry behavior.
-// This function implements smart HTTP response handling by examining status codes
-// and marking 4xx errors as unrecoverable.
-//
-// Parameters:
-//   - res: The response from the function (any type T)
-//   - err: The error from the function (may be nil)
-//
-// Returns:
-//   - T: The response (potentially modified)
-//   - error: The error (potentially wrapped or modified)
-func checkResAndErr[T any](res T, err error) (T, error) {
-	if err == nil {
-		switch r := any(res).(type) {
-		case *http.Response:
-			switch r.StatusCode {
-			case http.StatusNotImplemented:
-				return res, retry.Unrecoverable(fmt.Errorf("HTTP failed: %w", ErrNotImplemented))
-			case http.StatusBadRequest:
-				return res, retry.Unrecoverable(fmt.Errorf("HTTP failed: %w", ErrBadRequest))
-			case http.StatusForbidden:
-				return res, retry.Unrecoverable(fmt.Errorf("HTTP failed: %w", ErrForbidden))
-			case http.StatusNotFound:
-				return res, retry.Unrecoverable(fmt.Errorf("HTTP failed: %w", ErrNotFound))
-			default:
-				return res, err
+			shouldRetry, reason := cfg.policy.ShouldRetry(ctx, attempt, res, err)
+
+			attemptErr := reason
+			if attemptErr == nil {
+				attemptErr = err
 			}
-		default:
-			return res, nil
-		}
-	}
 
-	return res, err
-}
+			if !shouldRetry {
+				if reason != nil {
+					attemptErrs = append(attemptErrs, reason)
 
-// retryAction implements the retry.RetryIfFunc interface to determine whether
-// a retry should be attempted based on the error.
-// This function is called by retry-go before each retry attempt to decide
-// if the operation should be retried.
-//
-// RetryIfFunc signature: func(err error) bool
-//
-// Parameters:
-//   - err: The error from the failed attempt
-//
-// Returns:
-//   - bool: true if retry should be attempted, false otherwise
-func retryAction(err error) bool {
-	// Check if the error is recoverable using retry-go's built-in logic
-	// Unrecoverable errors (created with retry.Unrecoverable()) will return false
-	if !retry.IsRecoverable(err) {
-		return false
-	}
+					return retry.Unrecoverable(reason)
+				}
 
-	// Increment the attempt counter (used for testing purposes)
-	count++
+				return nil
+			}
 
-	return true
-}
+			// retry-go treats a nil error as success and stops retrying, so a
+			// policy that asks to retry without a reason (possible for a
+			// third-party RetryPolicy; see the ShouldRetry doc) must not
+			// reach retry.Do as nil.
+			if attemptErr == nil {
+				attemptErr = errRetryWithoutReason
+			}
 
-// GetCount returns the number of retry attempts made during the last Retry() call.
-//
-// Return values:
-//   - 0: Success on first attempt (no retries needed)
-//   - 1+: Number of retry attempts made
-//
-// Note: This function is not thread-safe due to the global count variable.
-// In a concurrent environment, multiple goroutines calling Retry() simultaneously
-// may interfere with each other's count values.
-func GetCount() int {
-	return count
+			attemptErrs = append(attemptErrs, attemptErr)
+
+			// The last allowed attempt is not followed by another one, so it
+			// doesn't count as a retry.
+			if cfg.onRetry != nil && uint(attempt) < maxAttempts {
+				cfg.onRetry(attempt, res, attemptErr)
+			}
+
+			return attemptErr
+		},
+		retryOpts...,
+	)
+
+	if err != nil && cfg.onGiveUp != nil {
+		cfg.onGiveUp(res, err)
+	}
+
+	result := Result[T]{
+		Value:          res,
+		Attempts:       attempt,
+		TotalDuration:  time.Since(start),
+		LastStatusCode: lastStatusCode,
+		Errors:         attemptErrs,
+	}
+
+	return res, result, err
 }
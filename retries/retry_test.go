@@ -3,6 +3,7 @@ package retries_test
 import (
 	"context"
 	"net/http"
+	"syscall"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,10 +13,10 @@ import (
 
 func TestRetry(t *testing.T) {
 	tests := []struct {
-		name          string
-		f             func() (any, error)
-		expectedRetry int
-		isError       bool
+		name             string
+		f                func() (any, error)
+		expectedAttempts int
+		isError          bool
 	}{
 		{
 			name: "functional error",
@@ -26,8 +27,8 @@ func TestRetry(t *testing.T) {
 
 				return &res, nil
 			},
-			isError:       true,
-			expectedRetry: 0,
+			isError:          true,
+			expectedAttempts: 1,
 		},
 		{
 			name: "Network issue 3 retries",
@@ -44,6 +45,7 @@ func TestRetry(t *testing.T) {
 					StatusCode: http.StatusInternalServerError,
 				}, nil
 			},
+			expectedAttempts: 1,
 		},
 	}
 
@@ -51,16 +53,82 @@ func TestRetry(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			res, err := retries.Retry(ctx, test.f)
+			res, result, err := retries.Retry(ctx, test.f)
+
+			assert.Equal(t, test.expectedAttempts, result.Attempts)
 
 			if test.isError {
-				assert.Equal(t, test.expectedRetry, retries.GetCount())
 				require.Error(t, err)
 			} else {
-				assert.Equal(t, test.expectedRetry, retries.GetCount())
 				require.NoError(t, err)
 				assert.NotEmpty(t, res)
 			}
 		})
 	}
 }
+
+type retryWithoutReasonPolicy struct{}
+
+func (retryWithoutReasonPolicy) ShouldRetry(_ context.Context, attempt int, _ any, _ error) (bool, error) {
+	return attempt < 3, nil
+}
+
+func TestRetryPolicyRetryWithoutReasonDoesNotStopEarly(t *testing.T) {
+	ctx := context.TODO()
+
+	calls := 0
+	_, result, err := retries.Retry(ctx, func() (int, error) {
+		calls++
+
+		return calls, nil
+	}, retries.WithPolicy(retryWithoutReasonPolicy{}))
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 3, result.Attempts)
+}
+
+func TestRetryWithAttempts(t *testing.T) {
+	ctx := context.TODO()
+
+	calls := 0
+	_, result, err := retries.Retry(ctx, func() (int, error) {
+		calls++
+
+		return 0, syscall.ECONNRESET
+	}, retries.WithAttempts(3))
+
+	require.Error(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 3, result.Attempts)
+}
+
+func TestRetryIsSafeForConcurrentCalls(t *testing.T) {
+	ctx := context.TODO()
+
+	const goroutines = 10
+
+	results := make(chan retries.Result[int], goroutines)
+	for i := 0; i < goroutines; i++ {
+		attempts := i%3 + 1
+
+		go func() {
+			calls := 0
+			_, result, err := retries.Retry(ctx, func() (int, error) {
+				calls++
+				if calls < attempts {
+					return 0, syscall.ECONNRESET
+				}
+
+				return calls, nil
+			})
+			require.NoError(t, err)
+			results <- result
+		}()
+	}
+
+	for i := 0; i < goroutines; i++ {
+		result := <-results
+		assert.Equal(t, result.Value, result.Attempts)
+	}
+}